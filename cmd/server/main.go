@@ -0,0 +1,90 @@
+// Command server runs the address lookup HTTP API.
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"golang.org/x/time/rate"
+
+	_ "github.com/lib/pq"
+
+	"go-server-v1/internal/auth"
+	"go-server-v1/internal/cache"
+	"go-server-v1/internal/config"
+	"go-server-v1/internal/geoapi"
+	"go-server-v1/internal/handler"
+	"go-server-v1/internal/repository"
+	"go-server-v1/internal/service"
+)
+
+func main() {
+
+	// Load .env file
+	if err := godotenv.Load(); err != nil {
+		log.Fatal("Error loading .env file")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Create database connection
+	connStr := fmt.Sprintf("host=%s user=postgres password=%s dbname=%s sslmode=disable", cfg.DBHost, cfg.Password, cfg.DBName)
+	db, err := sql.Open("postgres", connStr)
+	if err != nil {
+		log.Fatal("Failed to connect to database:", err)
+	}
+	defer func() {
+		if err := db.Close(); err != nil {
+			fmt.Println("Error closing database:", err.Error())
+		}
+	}()
+
+	// Load the prefecture/city polygon index used by /address/by_coords.
+	// This is best-effort: if it is unreadable we log and keep running,
+	// since coordinate resolution is an additive feature.
+	adminAreas, err := service.LoadAdminAreas(cfg.GeoJSONDir)
+	if err != nil {
+		log.Println("Warning: failed to load admin area polygons:", err)
+	}
+
+	addressCache := cache.New(cache.Config{
+		Host:     cfg.CacheHost,
+		Port:     cfg.CachePort,
+		Password: cfg.CachePassword,
+		TTL:      time.Duration(cfg.CacheTTLSeconds) * time.Second,
+	})
+
+	accessLogs := repository.NewAccessLogRepository(db)
+	users := repository.NewUserRepository(db)
+	addressService := service.NewAddressService(geoapi.NewClient(), addressCache, adminAreas)
+	jwtSecret := []byte(cfg.JWTSecret)
+	h := handler.New(accessLogs, users, addressService, addressCache, jwtSecret, cfg.TokenTTL)
+
+	e := echo.New()
+
+	// Middleware functions for logging and error recovery
+	e.Use(middleware.Logger())
+	e.Use(middleware.Recover())
+
+	// Routes
+	e.POST("/users/tokens", h.IssueToken)
+
+	e.GET("/address", h.GetAddress, auth.OptionalAuth(jwtSecret), middleware.RateLimiter(
+		middleware.NewRateLimiterMemoryStore(rate.Limit(cfg.AddressRateRPS)),
+	))
+	e.GET("/address/access_logs", h.GetAccessLogs, auth.RequireAuth(jwtSecret))
+	e.GET("/address/access_logs/predict", h.PredictAccessLogs, auth.RequireAuth(jwtSecret))
+	e.GET("/address/by_coords", h.GetAddressByCoords)
+	e.GET("/metrics", h.GetMetrics)
+
+	// Start the server
+	e.Start(":8080")
+}
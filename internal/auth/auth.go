@@ -0,0 +1,83 @@
+// Package auth issues and verifies the JWTs used to protect admin
+// endpoints and to attribute /address requests to a user when possible.
+package auth
+
+import (
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+// ContextKey is the echo.Context key RequireAuth stores the parsed token
+// under and OptionalAuth stores the request's claims under.
+const ContextKey = "user"
+
+// Claims is the JWT payload issued by POST /users/tokens.
+type Claims struct {
+	UserID string `json:"user_id"`
+	jwt.RegisteredClaims
+}
+
+// IssueToken signs a Claims for userID, valid for ttl, using secret.
+func IssueToken(secret []byte, userID string, ttl time.Duration) (string, error) {
+	claims := Claims{
+		UserID: userID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(ttl)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString(secret)
+}
+
+// RequireAuth rejects requests without a valid JWT. Used to protect
+// /address/access_logs.
+func RequireAuth(secret []byte) echo.MiddlewareFunc {
+	return middleware.JWTWithConfig(middleware.JWTConfig{
+		SigningKey: secret,
+		Claims:     &Claims{},
+		ContextKey: ContextKey,
+	})
+}
+
+// OptionalAuth parses a bearer JWT if present and stashes the resulting
+// Claims in the request context under ContextKey, but lets the request
+// through either way. Used on /address so a postal code lookup can be
+// attributed to a user when the caller happens to be authenticated.
+func OptionalAuth(secret []byte) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			tokenString := strings.TrimPrefix(c.Request().Header.Get("Authorization"), "Bearer ")
+			if tokenString != "" {
+				claims := &Claims{}
+				_, err := jwt.ParseWithClaims(tokenString, claims, func(*jwt.Token) (interface{}, error) {
+					return secret, nil
+				})
+				if err == nil {
+					c.Set(ContextKey, claims)
+				}
+			}
+			return next(c)
+		}
+	}
+}
+
+// UserIDFromContext returns the authenticated user id, if any, regardless
+// of whether it was set by RequireAuth (which stores the raw *jwt.Token)
+// or OptionalAuth (which stores the *Claims directly).
+func UserIDFromContext(c echo.Context) (string, bool) {
+	switch claims := c.Get(ContextKey).(type) {
+	case *Claims:
+		return claims.UserID, true
+	case *jwt.Token:
+		if parsed, ok := claims.Claims.(*Claims); ok {
+			return parsed.UserID, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,89 @@
+// Package cache memoizes AppResponse lookups in Redis, keyed by postal
+// code, with singleflight protection against stampedes on a cold key.
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"golang.org/x/sync/singleflight"
+
+	"go-server-v1/internal/model"
+)
+
+// Store is the interface AddressService and Handler depend on, so tests
+// can substitute a fake that never touches Redis.
+type Store interface {
+	GetOrFetch(ctx context.Context, postalCode string, fetch func() (model.AppResponse, error)) (model.AppResponse, error)
+	Stats() (hits, misses int64)
+}
+
+// Cache wraps a Redis client used to memoize AppResponse lookups.
+type Cache struct {
+	client *redis.Client
+	ttl    time.Duration
+	group  singleflight.Group
+
+	hits   int64
+	misses int64
+}
+
+// Config configures a new Cache.
+type Config struct {
+	Host     string
+	Port     string
+	Password string
+	TTL      time.Duration
+}
+
+// New builds a Cache from the given Config.
+func New(cfg Config) *Cache {
+	client := redis.NewClient(&redis.Options{
+		Addr:     fmt.Sprintf("%s:%s", cfg.Host, cfg.Port),
+		Password: cfg.Password,
+	})
+
+	return &Cache{client: client, ttl: cfg.TTL}
+}
+
+// GetOrFetch returns the cached AppResponse for postalCode if present;
+// otherwise it calls fetch exactly once per key (even under concurrent
+// callers, via singleflight) and populates the cache with the result.
+func (cache *Cache) GetOrFetch(ctx context.Context, postalCode string, fetch func() (model.AppResponse, error)) (model.AppResponse, error) {
+	if cached, err := cache.client.Get(ctx, postalCode).Result(); err == nil {
+		atomic.AddInt64(&cache.hits, 1)
+		var appResponse model.AppResponse
+		if err := json.Unmarshal([]byte(cached), &appResponse); err == nil {
+			return appResponse, nil
+		}
+	}
+
+	atomic.AddInt64(&cache.misses, 1)
+
+	result, err, _ := cache.group.Do(postalCode, func() (interface{}, error) {
+		appResponse, err := fetch()
+		if err != nil {
+			return model.AppResponse{}, err
+		}
+
+		if encoded, err := json.Marshal(appResponse); err == nil {
+			cache.client.Set(ctx, postalCode, encoded, cache.ttl)
+		}
+
+		return appResponse, nil
+	})
+	if err != nil {
+		return model.AppResponse{}, err
+	}
+
+	return result.(model.AppResponse), nil
+}
+
+// Stats returns the current hit/miss counters, used by the /metrics route.
+func (cache *Cache) Stats() (hits, misses int64) {
+	return atomic.LoadInt64(&cache.hits), atomic.LoadInt64(&cache.misses)
+}
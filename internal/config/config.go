@@ -0,0 +1,88 @@
+// Package config loads application configuration from a TOML file layered
+// with environment variable overrides, writing a default config file on
+// first run so deployments have something to edit in place.
+package config
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// Config holds every setting the server needs at startup.
+type Config struct {
+	DBHost   string
+	DBName   string
+	Password string
+
+	CacheHost       string
+	CachePort       string
+	CachePassword   string
+	CacheTTLSeconds int
+
+	GeoJSONDir string
+
+	JWTSecret      string
+	TokenTTL       time.Duration
+	AddressRateRPS float64
+}
+
+// Load reads ./config.toml, creating it with defaults if it does not
+// exist, then layers environment variables (e.g. DB_HOST, CACHE_TTL) on
+// top. PASSWORD must be set via either the file or the environment.
+func Load() (Config, error) {
+	viper.SetConfigName("config")
+	viper.SetConfigType("toml")
+	viper.AddConfigPath(".")
+
+	viper.SetDefault("db_host", "localhost")
+	viper.SetDefault("db_name", "postgres")
+	viper.SetDefault("cache_host", "localhost")
+	viper.SetDefault("cache_port", "6379")
+	viper.SetDefault("cache_ttl", 300)
+	viper.SetDefault("geojson_dir", "./data/geojson")
+	viper.SetDefault("token_ttl_minutes", 60)
+	viper.SetDefault("address_rate_rps", 5)
+
+	viper.AutomaticEnv()
+	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+
+	if err := viper.ReadInConfig(); err != nil {
+		var notFound viper.ConfigFileNotFoundError
+		if !errors.As(err, &notFound) {
+			return Config{}, err
+		}
+		if err := viper.SafeWriteConfigAs("./config.toml"); err != nil {
+			return Config{}, err
+		}
+	}
+
+	password := viper.GetString("password")
+	if password == "" {
+		return Config{}, errors.New("missing or invalid database password")
+	}
+
+	jwtSecret := viper.GetString("jwt_secret")
+	if jwtSecret == "" {
+		return Config{}, errors.New("missing or invalid jwt secret")
+	}
+
+	return Config{
+		DBHost:   viper.GetString("db_host"),
+		DBName:   viper.GetString("db_name"),
+		Password: password,
+
+		CacheHost:       viper.GetString("cache_host"),
+		CachePort:       viper.GetString("cache_port"),
+		CachePassword:   viper.GetString("cache_password"),
+		CacheTTLSeconds: viper.GetInt("cache_ttl"),
+
+		GeoJSONDir: viper.GetString("geojson_dir"),
+
+		JWTSecret:      jwtSecret,
+		TokenTTL:       time.Duration(viper.GetInt("token_ttl_minutes")) * time.Minute,
+		AddressRateRPS: viper.GetFloat64("address_rate_rps"),
+	}, nil
+}
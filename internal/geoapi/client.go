@@ -0,0 +1,155 @@
+// Package geoapi is the client for the geoapi.heartrails.com postal code
+// lookup API.
+package geoapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/valyala/fasthttp"
+
+	"go-server-v1/internal/model"
+)
+
+// Client resolves a postal code to heartrails location candidates.
+type Client interface {
+	SearchByPostal(ctx context.Context, postalCode string) (model.APIResponse, error)
+}
+
+// ErrUpstreamUnavailable is returned when heartrails cannot be reached
+// after retries, or while the circuit breaker is open, as opposed to a
+// client-side error (e.g. a malformed response body).
+var ErrUpstreamUnavailable = errors.New("heartrails upstream unavailable")
+
+const maxRetries = 3
+
+// heartrailsClient is a fasthttp-backed Client with connection pooling,
+// retry with exponential backoff, and a circuit breaker that opens after
+// consecutive failures.
+type heartrailsClient struct {
+	http    *fasthttp.Client
+	breaker *breaker
+}
+
+// NewClient returns a Client backed by a pooled fasthttp.Client.
+func NewClient() Client {
+	return &heartrailsClient{
+		http: &fasthttp.Client{
+			MaxConnsPerHost: 64,
+			ReadTimeout:     5 * time.Second,
+			WriteTimeout:    5 * time.Second,
+		},
+		breaker: &breaker{consecutiveFailureLimit: 5, cooldown: 30 * time.Second},
+	}
+}
+
+func (c *heartrailsClient) SearchByPostal(ctx context.Context, postalCode string) (model.APIResponse, error) {
+	body, err := c.fetch(ctx, postalCode)
+	if err != nil {
+		return model.APIResponse{}, err
+	}
+
+	var apiResponse model.APIResponse
+	if err := json.Unmarshal(body, &apiResponse); err != nil {
+		return model.APIResponse{}, err
+	}
+
+	return apiResponse, nil
+}
+
+// fetch calls the heartrails searchByPostal API for postalCode, honoring
+// ctx's deadline, retrying 5xx responses and timeouts with exponential
+// backoff, and short-circuiting while the breaker is open.
+func (c *heartrailsClient) fetch(ctx context.Context, postalCode string) ([]byte, error) {
+	if !c.breaker.allow() {
+		return nil, ErrUpstreamUnavailable
+	}
+
+	url := fmt.Sprintf("https://geoapi.heartrails.com/api/json?method=searchByPostal&postal=%s", postalCode)
+
+	var lastErr error
+	for attempt := 0; attempt < maxRetries; attempt++ {
+		if attempt > 0 {
+			backoff := time.Duration(math.Pow(2, float64(attempt))) * 100 * time.Millisecond
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+
+		req := fasthttp.AcquireRequest()
+		resp := fasthttp.AcquireResponse()
+
+		req.SetRequestURI(url)
+		req.Header.SetMethod(fasthttp.MethodGet)
+
+		deadline, ok := ctx.Deadline()
+		if !ok {
+			deadline = time.Now().Add(5 * time.Second)
+		}
+
+		err := c.http.DoDeadline(req, resp, deadline)
+		statusCode := resp.StatusCode()
+		var body []byte
+		if err == nil {
+			body = append(body, resp.Body()...)
+		}
+
+		fasthttp.ReleaseRequest(req)
+		fasthttp.ReleaseResponse(resp)
+
+		if err != nil {
+			lastErr = err
+			c.breaker.recordFailure()
+			continue
+		}
+
+		if statusCode >= 500 {
+			lastErr = fmt.Errorf("heartrails returned status %d", statusCode)
+			c.breaker.recordFailure()
+			continue
+		}
+
+		c.breaker.recordSuccess()
+		return body, nil
+	}
+
+	return nil, fmt.Errorf("%w: %v", ErrUpstreamUnavailable, lastErr)
+}
+
+// breaker opens after consecutiveFailureLimit consecutive request
+// failures and rejects calls for cooldown before allowing a retry.
+type breaker struct {
+	mu                      sync.Mutex
+	consecutiveFailures     int
+	consecutiveFailureLimit int
+	cooldown                time.Duration
+	openUntil               time.Time
+}
+
+func (b *breaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return time.Now().After(b.openUntil)
+}
+
+func (b *breaker) recordSuccess() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.consecutiveFailures = 0
+}
+
+func (b *breaker) recordFailure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.consecutiveFailures++
+	if b.consecutiveFailures >= b.consecutiveFailureLimit {
+		b.openUntil = time.Now().Add(b.cooldown)
+	}
+}
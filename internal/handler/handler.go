@@ -0,0 +1,208 @@
+// Package handler wires Echo routes to the service and repository layers.
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"go-server-v1/internal/auth"
+	"go-server-v1/internal/cache"
+	"go-server-v1/internal/geoapi"
+	"go-server-v1/internal/model"
+	"go-server-v1/internal/repository"
+	"go-server-v1/internal/service"
+)
+
+// Handler holds the collaborators needed to serve every route.
+type Handler struct {
+	accessLogs repository.AccessLogRepository
+	users      repository.UserRepository
+	address    *service.AddressService
+	cache      cache.Store
+	jwtSecret  []byte
+	tokenTTL   time.Duration
+}
+
+// New wires up a Handler from its collaborators.
+func New(accessLogs repository.AccessLogRepository, users repository.UserRepository, address *service.AddressService, cache cache.Store, jwtSecret []byte, tokenTTL time.Duration) *Handler {
+	return &Handler{accessLogs: accessLogs, users: users, address: address, cache: cache, jwtSecret: jwtSecret, tokenTTL: tokenTTL}
+}
+
+// IssueToken handles POST /users/tokens, minting a JWT for user_id once
+// api_key has been verified against the pre-provisioned users table (see
+// migrations/0002_create_users.sql).
+func (h *Handler) IssueToken(c echo.Context) error {
+	var body struct {
+		UserID string `json:"user_id"`
+		APIKey string `json:"api_key"`
+	}
+	if err := c.Bind(&body); err != nil || body.UserID == "" || body.APIKey == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "user_id and api_key are required"})
+	}
+
+	authenticated, err := h.users.Authenticate(body.UserID, body.APIKey)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	if !authenticated {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "invalid credentials"})
+	}
+
+	token, err := auth.IssueToken(h.jwtSecret, body.UserID, h.tokenTTL)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"token": token})
+}
+
+// GetAddress handles GET /address.
+func (h *Handler) GetAddress(c echo.Context) error {
+	postalCode := c.QueryParam("postal_code")
+
+	var userID *string
+	if id, ok := auth.UserIDFromContext(c); ok {
+		userID = &id
+	}
+
+	if err := h.accessLogs.Insert(postalCode, userID, time.Now()); err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	bypassCache := c.QueryParam("no-cache") != ""
+	appResponse, err := h.address.ResolveAddress(c.Request().Context(), postalCode, bypassCache)
+	if errors.Is(err, geoapi.ErrUpstreamUnavailable) {
+		return c.JSON(http.StatusBadGateway, map[string]string{"error": "upstream address service unavailable"})
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, appResponse)
+}
+
+// GetAddressByCoords handles GET /address/by_coords.
+func (h *Handler) GetAddressByCoords(c echo.Context) error {
+	lat, err := strconv.ParseFloat(c.QueryParam("lat"), 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid or missing lat"})
+	}
+
+	lon, err := strconv.ParseFloat(c.QueryParam("lon"), 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid or missing lon"})
+	}
+
+	address, err := h.address.ResolveByCoords(lat, lon)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"address": address})
+}
+
+var validIntervals = map[string]bool{"hour": true, "day": true, "week": true}
+
+// GetAccessLogs handles GET /address/access_logs. With no query params it
+// returns the all-time count per postal code, as before. Passing from,
+// to, interval and/or top_n instead returns bucketed time-series counts
+// per postal code, suitable for charting.
+func (h *Handler) GetAccessLogs(c echo.Context) error {
+	if c.QueryParam("from") == "" && c.QueryParam("to") == "" &&
+		c.QueryParam("interval") == "" && c.QueryParam("top_n") == "" {
+		logs, err := h.accessLogs.CountByPostalCode()
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusOK, map[string]interface{}{"access_logs": logs})
+	}
+
+	interval := c.QueryParam("interval")
+	if interval == "" {
+		interval = "day"
+	}
+	if !validIntervals[interval] {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "interval must be one of hour, day, week"})
+	}
+
+	from, err := parseTimeParam(c.QueryParam("from"), time.Unix(0, 0))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "from must be RFC3339"})
+	}
+
+	to, err := parseTimeParam(c.QueryParam("to"), time.Now())
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "to must be RFC3339"})
+	}
+
+	topN := 10
+	if raw := c.QueryParam("top_n"); raw != "" {
+		topN, err = strconv.Atoi(raw)
+		if err != nil || topN <= 0 {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "top_n must be a positive integer"})
+		}
+	}
+
+	buckets, err := h.accessLogs.BucketedCounts(from, to, interval, topN)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{"access_logs": buckets})
+}
+
+// PredictAccessLogs handles GET /address/access_logs/predict, forecasting
+// the next hour's request count for a postal code from its hourly series
+// over the trailing week via an exponentially weighted moving average.
+func (h *Handler) PredictAccessLogs(c echo.Context) error {
+	postalCode := c.QueryParam("postal_code")
+	if postalCode == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "postal_code is required"})
+	}
+
+	alpha := 0.3
+	if raw := c.QueryParam("alpha"); raw != "" {
+		parsed, err := strconv.ParseFloat(raw, 64)
+		if err != nil || parsed <= 0 || parsed > 1 {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "alpha must be in (0, 1]"})
+		}
+		alpha = parsed
+	}
+
+	to := time.Now()
+	from := to.Add(-7 * 24 * time.Hour)
+	buckets, err := h.accessLogs.SeriesForPostalCode(postalCode, from, to, "hour")
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	counts := make([]float64, len(buckets))
+	for i, bucket := range buckets {
+		counts[i] = float64(bucket.RequestCount)
+	}
+
+	predicted, confidence := service.PredictNextHour(counts, alpha)
+
+	return c.JSON(http.StatusOK, model.PredictionResponse{
+		PostalCode:        postalCode,
+		PredictedNextHour: predicted,
+		Confidence:        confidence,
+	})
+}
+
+func parseTimeParam(raw string, fallback time.Time) (time.Time, error) {
+	if raw == "" {
+		return fallback, nil
+	}
+	return time.Parse(time.RFC3339, raw)
+}
+
+// GetMetrics handles GET /metrics, exposing cache hit/miss counts.
+func (h *Handler) GetMetrics(c echo.Context) error {
+	hits, misses := h.cache.Stats()
+	return c.JSON(http.StatusOK, map[string]int64{"cache_hits": hits, "cache_misses": misses})
+}
@@ -0,0 +1,285 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"go-server-v1/internal/geoapi"
+	"go-server-v1/internal/model"
+	"go-server-v1/internal/service"
+)
+
+// fakeAccessLogRepository is an in-memory repository.AccessLogRepository
+// for exercising handlers without a database.
+type fakeAccessLogRepository struct {
+	insertErr error
+
+	counts    []model.AccessLog
+	countsErr error
+
+	buckets    []model.AccessLogBucket
+	bucketsErr error
+
+	series    []model.AccessLogBucket
+	seriesErr error
+}
+
+func (f *fakeAccessLogRepository) Insert(postalCode string, userID *string, requestedAt time.Time) error {
+	return f.insertErr
+}
+
+func (f *fakeAccessLogRepository) CountByPostalCode() ([]model.AccessLog, error) {
+	return f.counts, f.countsErr
+}
+
+func (f *fakeAccessLogRepository) BucketedCounts(from, to time.Time, interval string, topN int) ([]model.AccessLogBucket, error) {
+	return f.buckets, f.bucketsErr
+}
+
+func (f *fakeAccessLogRepository) SeriesForPostalCode(postalCode string, from, to time.Time, interval string) ([]model.AccessLogBucket, error) {
+	return f.series, f.seriesErr
+}
+
+// fakeUserRepository is an in-memory repository.UserRepository backed by a
+// single known-good credential pair.
+type fakeUserRepository struct {
+	userID string
+	apiKey string
+	err    error
+}
+
+func (f *fakeUserRepository) Authenticate(userID, apiKey string) (bool, error) {
+	if f.err != nil {
+		return false, f.err
+	}
+	return userID == f.userID && apiKey == f.apiKey, nil
+}
+
+// fakeGeoClient is an in-memory geoapi.Client.
+type fakeGeoClient struct {
+	response model.APIResponse
+	err      error
+}
+
+func (f *fakeGeoClient) SearchByPostal(ctx context.Context, postalCode string) (model.APIResponse, error) {
+	return f.response, f.err
+}
+
+// fakeCacheStore is a cache.Store that never touches Redis: it always
+// misses and calls through to fetch directly.
+type fakeCacheStore struct {
+	hits, misses int64
+}
+
+func (f *fakeCacheStore) GetOrFetch(ctx context.Context, postalCode string, fetch func() (model.AppResponse, error)) (model.AppResponse, error) {
+	return fetch()
+}
+
+func (f *fakeCacheStore) Stats() (hits, misses int64) {
+	return f.hits, f.misses
+}
+
+func TestIssueToken(t *testing.T) {
+	users := &fakeUserRepository{userID: "alice", apiKey: "correct-key"}
+	h := New(&fakeAccessLogRepository{}, users, nil, &fakeCacheStore{}, []byte("secret"), time.Minute)
+
+	t.Run("valid credentials mint a token", func(t *testing.T) {
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/users/tokens", strings.NewReader(`{"user_id":"alice","api_key":"correct-key"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		if err := h.IssueToken(c); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if rec.Code != http.StatusOK {
+			t.Fatalf("got status %d, want %d", rec.Code, http.StatusOK)
+		}
+
+		var body struct {
+			Token string `json:"token"`
+		}
+		if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+			t.Fatalf("unmarshalling response: %v", err)
+		}
+		if body.Token == "" {
+			t.Error("expected a non-empty token")
+		}
+	})
+
+	t.Run("invalid credentials are rejected", func(t *testing.T) {
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/users/tokens", strings.NewReader(`{"user_id":"alice","api_key":"wrong-key"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		if err := h.IssueToken(c); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if rec.Code != http.StatusUnauthorized {
+			t.Fatalf("got status %d, want %d", rec.Code, http.StatusUnauthorized)
+		}
+	})
+}
+
+func TestGetAddress(t *testing.T) {
+	apiResponse := model.APIResponse{
+		Response: model.Response{
+			Location: []model.Location{
+				{Prefecture: "東京都", City: "千代田区", Town: "丸の内", X: "139.7673068", Y: "35.6809591"},
+			},
+		},
+	}
+
+	addressService := service.NewAddressService(&fakeGeoClient{response: apiResponse}, &fakeCacheStore{}, nil)
+	h := New(&fakeAccessLogRepository{}, &fakeUserRepository{}, addressService, &fakeCacheStore{}, []byte("secret"), time.Minute)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/address?postal_code=100-0001", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	if err := h.GetAddress(c); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rec.Code != http.StatusOK {
+		t.Fatalf("got status %d, want %d", rec.Code, http.StatusOK)
+	}
+
+	var appResponse model.AppResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &appResponse); err != nil {
+		t.Fatalf("unmarshalling response: %v", err)
+	}
+	if appResponse.Address != "東京都千代田区丸の内" {
+		t.Errorf("got address %q", appResponse.Address)
+	}
+}
+
+func TestGetAddressUpstreamUnavailable(t *testing.T) {
+	addressService := service.NewAddressService(&fakeGeoClient{err: geoapi.ErrUpstreamUnavailable}, &fakeCacheStore{}, nil)
+	h := New(&fakeAccessLogRepository{}, &fakeUserRepository{}, addressService, &fakeCacheStore{}, []byte("secret"), time.Minute)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/address?postal_code=100-0001", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	if err := h.GetAddress(c); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rec.Code != http.StatusBadGateway {
+		t.Fatalf("got status %d, want %d", rec.Code, http.StatusBadGateway)
+	}
+}
+
+func TestGetAccessLogsDefault(t *testing.T) {
+	repo := &fakeAccessLogRepository{counts: []model.AccessLog{{PostalCode: "100-0001", RequestCount: 3}}}
+	h := New(repo, &fakeUserRepository{}, nil, &fakeCacheStore{}, []byte("secret"), time.Minute)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/address/access_logs", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	if err := h.GetAccessLogs(c); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rec.Code != http.StatusOK {
+		t.Fatalf("got status %d, want %d", rec.Code, http.StatusOK)
+	}
+	if !strings.Contains(rec.Body.String(), "100-0001") {
+		t.Errorf("response missing expected postal code: %s", rec.Body.String())
+	}
+}
+
+func TestGetAccessLogsBucketed(t *testing.T) {
+	repo := &fakeAccessLogRepository{buckets: []model.AccessLogBucket{{PostalCode: "100-0001", RequestCount: 2}}}
+	h := New(repo, &fakeUserRepository{}, nil, &fakeCacheStore{}, []byte("secret"), time.Minute)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/address/access_logs?interval=hour&top_n=5", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	if err := h.GetAccessLogs(c); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rec.Code != http.StatusOK {
+		t.Fatalf("got status %d, want %d", rec.Code, http.StatusOK)
+	}
+
+	e2 := echo.New()
+	req2 := httptest.NewRequest(http.MethodGet, "/address/access_logs?interval=fortnight", nil)
+	rec2 := httptest.NewRecorder()
+	c2 := e2.NewContext(req2, rec2)
+	if err := h.GetAccessLogs(c2); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rec2.Code != http.StatusBadRequest {
+		t.Fatalf("got status %d, want %d for invalid interval", rec2.Code, http.StatusBadRequest)
+	}
+}
+
+func TestPredictAccessLogs(t *testing.T) {
+	repo := &fakeAccessLogRepository{series: []model.AccessLogBucket{
+		{PostalCode: "100-0001", RequestCount: 10},
+		{PostalCode: "100-0001", RequestCount: 10},
+	}}
+	h := New(repo, &fakeUserRepository{}, nil, &fakeCacheStore{}, []byte("secret"), time.Minute)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/address/access_logs/predict?postal_code=100-0001", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	if err := h.PredictAccessLogs(c); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rec.Code != http.StatusOK {
+		t.Fatalf("got status %d, want %d", rec.Code, http.StatusOK)
+	}
+
+	var prediction model.PredictionResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &prediction); err != nil {
+		t.Fatalf("unmarshalling response: %v", err)
+	}
+	if prediction.PredictedNextHour != 10 {
+		t.Errorf("got predicted %v, want 10", prediction.PredictedNextHour)
+	}
+}
+
+func TestGetMetrics(t *testing.T) {
+	h := New(&fakeAccessLogRepository{}, &fakeUserRepository{}, nil, &fakeCacheStore{hits: 4, misses: 2}, []byte("secret"), time.Minute)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	if err := h.GetMetrics(c); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rec.Code != http.StatusOK {
+		t.Fatalf("got status %d, want %d", rec.Code, http.StatusOK)
+	}
+
+	var body struct {
+		CacheHits   int64 `json:"cache_hits"`
+		CacheMisses int64 `json:"cache_misses"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("unmarshalling response: %v", err)
+	}
+	if body.CacheHits != 4 || body.CacheMisses != 2 {
+		t.Errorf("got hits=%d misses=%d, want 4/2", body.CacheHits, body.CacheMisses)
+	}
+}
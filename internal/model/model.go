@@ -0,0 +1,56 @@
+// Package model holds the data types shared across the service, repository
+// and handler layers.
+package model
+
+import "time"
+
+// Location is a single result from the heartrails searchByPostal API.
+type Location struct {
+	City       string `json:"city"`
+	Town       string `json:"town"`
+	X          string `json:"x"`
+	Y          string `json:"y"`
+	Prefecture string `json:"prefecture"`
+	Postal     string `json:"postal"`
+}
+
+// Response wraps the list of Location results returned by heartrails.
+type Response struct {
+	Location []Location `json:"location"`
+}
+
+// APIResponse is the top-level shape of the heartrails searchByPostal
+// response.
+type APIResponse struct {
+	Response Response `json:"response"`
+}
+
+// AppResponse is the shape of our own /address API response.
+type AppResponse struct {
+	PostalCode       string  `json:"postal_code"`
+	HitCount         int     `json:"hit_count"`
+	Address          string  `json:"address"`
+	TokyoStaDistance float64 `json:"tokyo_sta_distance"`
+}
+
+// AccessLog is a single aggregated row from the access_logs table.
+type AccessLog struct {
+	PostalCode   string `json:"postal_code"`
+	RequestCount int    `json:"request_count"`
+}
+
+// AccessLogBucket is a single time-bucketed row from the access_logs
+// table, as returned by queries over a from/to window.
+type AccessLogBucket struct {
+	PostalCode   string    `json:"postal_code"`
+	Bucket       time.Time `json:"bucket"`
+	RequestCount int       `json:"request_count"`
+}
+
+// PredictionResponse is the shape of the
+// /address/access_logs/predict response.
+type PredictionResponse struct {
+	PostalCode        string  `json:"postal_code"`
+	PredictedNextHour float64 `json:"predicted_next_hour"`
+	Confidence        float64 `json:"confidence"`
+}
@@ -0,0 +1,124 @@
+// Package repository provides the postgres-backed access_logs store
+// behind an interface, so handlers and services can be unit-tested
+// against a mock instead of a live database.
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"go-server-v1/internal/model"
+)
+
+// AccessLogRepository records and aggregates /address lookups.
+type AccessLogRepository interface {
+	// Insert records a lookup. userID is nil when the request was
+	// unauthenticated; see migrations/0001_add_user_id_to_access_logs.sql.
+	Insert(postalCode string, userID *string, requestedAt time.Time) error
+	CountByPostalCode() ([]model.AccessLog, error)
+
+	// BucketedCounts returns time-bucketed request counts within
+	// [from, to], one bucket per interval ("hour", "day" or "week"),
+	// restricted to the topN postal codes by total request count in
+	// that window.
+	BucketedCounts(from, to time.Time, interval string, topN int) ([]model.AccessLogBucket, error)
+
+	// SeriesForPostalCode returns the time-bucketed request count series
+	// for a single postal code within [from, to].
+	SeriesForPostalCode(postalCode string, from, to time.Time, interval string) ([]model.AccessLogBucket, error)
+}
+
+type postgresAccessLogRepository struct {
+	db *sql.DB
+}
+
+// NewAccessLogRepository returns an AccessLogRepository backed by db.
+func NewAccessLogRepository(db *sql.DB) AccessLogRepository {
+	return &postgresAccessLogRepository{db: db}
+}
+
+func (r *postgresAccessLogRepository) Insert(postalCode string, userID *string, requestedAt time.Time) error {
+	_, err := r.db.Exec("INSERT INTO access_logs (postal_code, user_id, created_at) VALUES ($1, $2, $3)", postalCode, userID, requestedAt)
+	return err
+}
+
+func (r *postgresAccessLogRepository) CountByPostalCode() ([]model.AccessLog, error) {
+	rows, err := r.db.Query("SELECT postal_code, COUNT(*) AS request_count FROM access_logs GROUP BY postal_code ORDER BY request_count DESC")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var logs []model.AccessLog
+	for rows.Next() {
+		var log model.AccessLog
+		if err := rows.Scan(&log.PostalCode, &log.RequestCount); err != nil {
+			return nil, err
+		}
+		logs = append(logs, log)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return logs, nil
+}
+
+func (r *postgresAccessLogRepository) BucketedCounts(from, to time.Time, interval string, topN int) ([]model.AccessLogBucket, error) {
+	rows, err := r.db.Query(`
+		WITH top_codes AS (
+			SELECT postal_code
+			FROM access_logs
+			WHERE created_at BETWEEN $1 AND $2
+			GROUP BY postal_code
+			ORDER BY COUNT(*) DESC
+			LIMIT $3
+		)
+		SELECT a.postal_code, date_trunc($4, a.created_at) AS bucket, COUNT(*) AS request_count
+		FROM access_logs a
+		JOIN top_codes t ON t.postal_code = a.postal_code
+		WHERE a.created_at BETWEEN $1 AND $2
+		GROUP BY a.postal_code, bucket
+		ORDER BY bucket ASC
+	`, from, to, topN, interval)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanBuckets(rows)
+}
+
+func (r *postgresAccessLogRepository) SeriesForPostalCode(postalCode string, from, to time.Time, interval string) ([]model.AccessLogBucket, error) {
+	rows, err := r.db.Query(`
+		SELECT postal_code, date_trunc($1, created_at) AS bucket, COUNT(*) AS request_count
+		FROM access_logs
+		WHERE postal_code = $2 AND created_at BETWEEN $3 AND $4
+		GROUP BY postal_code, bucket
+		ORDER BY bucket ASC
+	`, interval, postalCode, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanBuckets(rows)
+}
+
+func scanBuckets(rows *sql.Rows) ([]model.AccessLogBucket, error) {
+	var buckets []model.AccessLogBucket
+	for rows.Next() {
+		var bucket model.AccessLogBucket
+		if err := rows.Scan(&bucket.PostalCode, &bucket.Bucket, &bucket.RequestCount); err != nil {
+			return nil, err
+		}
+		buckets = append(buckets, bucket)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return buckets, nil
+}
@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/hex"
+)
+
+// UserRepository authenticates pre-provisioned users against their stored
+// API key hash, as required before POST /users/tokens mints a JWT.
+type UserRepository interface {
+	// Authenticate reports whether apiKey is the correct credential for
+	// userID. A user_id with no matching row is treated as invalid
+	// credentials, not an error.
+	Authenticate(userID, apiKey string) (bool, error)
+}
+
+type postgresUserRepository struct {
+	db *sql.DB
+}
+
+// NewUserRepository returns a UserRepository backed by db.
+func NewUserRepository(db *sql.DB) UserRepository {
+	return &postgresUserRepository{db: db}
+}
+
+func (r *postgresUserRepository) Authenticate(userID, apiKey string) (bool, error) {
+	var storedHash string
+	err := r.db.QueryRow("SELECT api_key_hash FROM users WHERE user_id = $1", userID).Scan(&storedHash)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	hash := sha256.Sum256([]byte(apiKey))
+	providedHash := hex.EncodeToString(hash[:])
+
+	return subtle.ConstantTimeCompare([]byte(storedHash), []byte(providedHash)) == 1, nil
+}
@@ -0,0 +1,141 @@
+// Package service implements the business logic for resolving postal codes
+// and coordinates to addresses, independent of the HTTP and storage layers.
+package service
+
+import (
+	"context"
+	"log"
+	"math"
+	"strconv"
+
+	"github.com/umahmood/haversine"
+
+	"go-server-v1/internal/cache"
+	"go-server-v1/internal/geoapi"
+	"go-server-v1/internal/model"
+)
+
+var tokyoX = 139.7673068
+var tokyoY = 35.6809591
+
+// AddressService resolves postal codes (via heartrails, with a cache in
+// front) and raw coordinates (via the local polygon index) to addresses.
+type AddressService struct {
+	geo        geoapi.Client
+	cache      cache.Store
+	adminAreas []AdminArea
+}
+
+// NewAddressService wires up an AddressService from its collaborators.
+func NewAddressService(geo geoapi.Client, cache cache.Store, adminAreas []AdminArea) *AddressService {
+	return &AddressService{geo: geo, cache: cache, adminAreas: adminAreas}
+}
+
+// ResolveAddress returns the AppResponse for postalCode, serving from cache
+// unless bypassCache is set.
+func (s *AddressService) ResolveAddress(ctx context.Context, postalCode string, bypassCache bool) (model.AppResponse, error) {
+	fetch := func() (model.AppResponse, error) {
+		return s.fetchAddress(ctx, postalCode)
+	}
+
+	if bypassCache {
+		return fetch()
+	}
+	return s.cache.GetOrFetch(ctx, postalCode, fetch)
+}
+
+// ResolveByCoords resolves raw lat/lon coordinates to an enclosing
+// administrative area using the local polygon index.
+func (s *AddressService) ResolveByCoords(lat, lon float64) (string, error) {
+	return ResolveByCoords(s.adminAreas, lat, lon)
+}
+
+func (s *AddressService) fetchAddress(ctx context.Context, postalCode string) (model.AppResponse, error) {
+	apiResponse, err := s.geo.SearchByPostal(ctx, postalCode)
+	if err != nil {
+		return model.AppResponse{}, err
+	}
+
+	// Find the shortest address and the maximum distance to Tokyo Station
+	var shortestAddress string
+	addresses := make([]string, len(apiResponse.Response.Location))
+	for i, location := range apiResponse.Response.Location {
+		addresses[i] = location.Prefecture + location.City + location.Town
+		if shortestAddress == "" || len(addresses[i]) < len(shortestAddress) {
+			shortestAddress = addresses[i]
+		}
+	}
+
+	// Calculate the distance to Tokyo Station
+	maxDistance := 0.0
+	for _, location := range apiResponse.Response.Location {
+		x, _ := strconv.ParseFloat(location.X, 64)
+		y, _ := strconv.ParseFloat(location.Y, 64)
+
+		_, km := haversine.Distance(haversine.Coord{Lat: tokyoY, Lon: tokyoX}, haversine.Coord{Lat: y, Lon: x})
+
+		if km > maxDistance {
+			maxDistance = km
+		}
+	}
+
+	appResponse := model.AppResponse{
+		PostalCode:       postalCode,
+		HitCount:         len(apiResponse.Response.Location),
+		Address:          CommonPrefix(addresses),
+		TokyoStaDistance: math.Round(maxDistance*10) / 10,
+	}
+
+	// Cross-check the heartrails result against the local polygon index,
+	// when available, and surface any mismatch for visibility. Compare at
+	// prefecture+city granularity on both sides, since that's what
+	// AdminArea names are built from when a feature has no name property
+	// (see newAdminArea; a name property is used as-is) - comparing
+	// against appResponse.Address (a prefecture+city+town common prefix)
+	// would almost never match.
+	if len(apiResponse.Response.Location) > 0 {
+		first := apiResponse.Response.Location[0]
+		x, errX := strconv.ParseFloat(first.X, 64)
+		y, errY := strconv.ParseFloat(first.Y, 64)
+		if errX == nil && errY == nil {
+			if localArea, err := ResolveByCoords(s.adminAreas, y, x); err == nil && !addressesMatch(localArea, first.Prefecture, first.City) {
+				log.Printf("address mismatch for postal code %s: heartrails=%q local=%q", postalCode, first.Prefecture+first.City, localArea)
+			}
+		}
+	}
+
+	return appResponse, nil
+}
+
+// addressesMatch reports whether a polygon-index area name agrees with the
+// heartrails prefecture+city for the same location.
+func addressesMatch(localArea, prefecture, city string) bool {
+	return localArea == prefecture+city
+}
+
+// CommonPrefix finds the common prefix among an array of strings.
+func CommonPrefix(strs []string) string {
+	if len(strs) == 0 {
+		return "Error: func commonPrefix"
+	}
+
+	prefix := []rune(strs[0])
+
+	for _, str := range strs {
+		strRune := []rune(str)
+		if len(strRune) < len(prefix) {
+			prefix = prefix[:len(strRune)]
+		}
+		for i := range prefix {
+			if prefix[i] != strRune[i] {
+				prefix = prefix[:i]
+				break
+			}
+		}
+	}
+
+	if len(prefix) == 0 {
+		return "None"
+	}
+	return string(prefix)
+}
@@ -0,0 +1,25 @@
+package service
+
+import "testing"
+
+func TestAddressesMatch(t *testing.T) {
+	tests := []struct {
+		name       string
+		localArea  string
+		prefecture string
+		city       string
+		want       bool
+	}{
+		{"agree", "東京都千代田区", "東京都", "千代田区", true},
+		{"disagree", "神奈川県横浜市", "東京都", "千代田区", false},
+		{"town suffix on neither side is fine", "東京都千代田区", "東京都", "千代田区", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := addressesMatch(tt.localArea, tt.prefecture, tt.city); got != tt.want {
+				t.Errorf("addressesMatch(%q, %q, %q) = %v, want %v", tt.localArea, tt.prefecture, tt.city, got, tt.want)
+			}
+		})
+	}
+}
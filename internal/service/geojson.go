@@ -0,0 +1,48 @@
+package service
+
+import "encoding/json"
+
+// Minimal GeoJSON decoding types, scoped to what LoadAdminAreas needs
+// (Polygon features with a single outer ring; holes are ignored).
+
+type geoJSONFeatureCollection struct {
+	Type     string           `json:"type"`
+	Features []geoJSONFeature `json:"features"`
+}
+
+type geoJSONFeature struct {
+	Type       string            `json:"type"`
+	Properties geoJSONProperties `json:"properties"`
+	Geometry   geoJSONGeometry   `json:"geometry"`
+}
+
+type geoJSONProperties struct {
+	Name       string `json:"name"`
+	Prefecture string `json:"prefecture"`
+	City       string `json:"city"`
+}
+
+// Geometry.Coordinates is decoded lazily by UnmarshalJSON depending on Type,
+// since GeoJSON nests coordinate arrays differently for Polygon vs
+// MultiPolygon. We only support Polygon for now.
+type geoJSONGeometry struct {
+	Type        string         `json:"type"`
+	Coordinates [][][2]float64 `json:"coordinates"`
+}
+
+// UnmarshalJSON decodes Coordinates only for Polygon geometries and leaves
+// it empty for any other type, so LoadAdminAreas can skip those features.
+func (g *geoJSONGeometry) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Type        string          `json:"type"`
+		Coordinates json.RawMessage `json:"coordinates"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	g.Type = raw.Type
+	if raw.Type != "Polygon" {
+		return nil
+	}
+	return json.Unmarshal(raw.Coordinates, &g.Coordinates)
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+)
+
+// AdminArea is a named administrative polygon (prefecture or city) loaded
+// from a GeoJSON feature, along with a precomputed bounding box and
+// centroid used for bbox prefiltering and nearest-centroid fallback.
+type AdminArea struct {
+	Name     string
+	Ring     [][2]float64 // outer ring, [lon, lat] pairs
+	MinX     float64
+	MinY     float64
+	MaxX     float64
+	MaxY     float64
+	Centroid [2]float64 // [lon, lat]
+}
+
+// LoadAdminAreas reads every *.geojson file in dir and builds the in-memory
+// polygon index used by ResolveByCoords. It is called once at startup.
+func LoadAdminAreas(dir string) ([]AdminArea, error) {
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("reading geojson dir: %w", err)
+	}
+
+	var areas []AdminArea
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".geojson" {
+			continue
+		}
+
+		data, err := ioutil.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", entry.Name(), err)
+		}
+
+		var fc geoJSONFeatureCollection
+		if err := json.Unmarshal(data, &fc); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", entry.Name(), err)
+		}
+
+		for _, feature := range fc.Features {
+			if feature.Geometry.Type != "Polygon" || len(feature.Geometry.Coordinates) == 0 {
+				continue
+			}
+			areas = append(areas, newAdminArea(feature))
+		}
+	}
+
+	return areas, nil
+}
+
+func newAdminArea(feature geoJSONFeature) AdminArea {
+	ring := feature.Geometry.Coordinates[0]
+
+	name := feature.Properties.Name
+	if name == "" {
+		name = feature.Properties.Prefecture + feature.Properties.City
+	}
+
+	area := AdminArea{Name: name, Ring: ring}
+	area.MinX, area.MinY = ring[0][0], ring[0][1]
+	area.MaxX, area.MaxY = ring[0][0], ring[0][1]
+
+	var sumX, sumY float64
+	for _, point := range ring {
+		x, y := point[0], point[1]
+		if x < area.MinX {
+			area.MinX = x
+		}
+		if x > area.MaxX {
+			area.MaxX = x
+		}
+		if y < area.MinY {
+			area.MinY = y
+		}
+		if y > area.MaxY {
+			area.MaxY = y
+		}
+		sumX += x
+		sumY += y
+	}
+	area.Centroid = [2]float64{sumX / float64(len(ring)), sumY / float64(len(ring))}
+
+	return area
+}
+
+// containsBBox reports whether (px, py) falls within the area's bounding
+// box, used to cheaply skip polygons before running the ray-casting test.
+func (a AdminArea) containsBBox(px, py float64) bool {
+	return px >= a.MinX && px <= a.MaxX && py >= a.MinY && py <= a.MaxY
+}
+
+// containsPoint runs a standard ray-casting point-in-polygon test against
+// the area's outer ring: for each edge (x_i, y_i) -> (x_j, y_j), count a
+// crossing when the edge straddles the point's latitude and the
+// intersection with that latitude falls to the east of the point.
+func (a AdminArea) containsPoint(px, py float64) bool {
+	inside := false
+	ring := a.Ring
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		xi, yi := ring[i][0], ring[i][1]
+		xj, yj := ring[j][0], ring[j][1]
+
+		if (yi > py) != (yj > py) && px < (xj-xi)*(py-yi)/(yj-yi)+xi {
+			inside = !inside
+		}
+	}
+	return inside
+}
@@ -0,0 +1,61 @@
+package service
+
+import "testing"
+
+// square returns a 10x10 AdminArea spanning (0,0)-(10,10), with its bbox
+// and centroid precomputed the way newAdminArea would.
+func square(name string) AdminArea {
+	return AdminArea{
+		Name:     name,
+		Ring:     [][2]float64{{0, 0}, {0, 10}, {10, 10}, {10, 0}},
+		MinX:     0,
+		MinY:     0,
+		MaxX:     10,
+		MaxY:     10,
+		Centroid: [2]float64{5, 5},
+	}
+}
+
+func TestAdminAreaContainsBBox(t *testing.T) {
+	area := square("square")
+
+	tests := []struct {
+		name string
+		x, y float64
+		want bool
+	}{
+		{"inside", 5, 5, true},
+		{"on corner", 0, 0, true},
+		{"outside", 20, 20, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := area.containsBBox(tt.x, tt.y); got != tt.want {
+				t.Errorf("containsBBox(%v, %v) = %v, want %v", tt.x, tt.y, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAdminAreaContainsPoint(t *testing.T) {
+	area := square("square")
+
+	tests := []struct {
+		name string
+		x, y float64
+		want bool
+	}{
+		{"center", 5, 5, true},
+		{"outside to the east", 15, 5, false},
+		{"on the boundary", 0, 5, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := area.containsPoint(tt.x, tt.y); got != tt.want {
+				t.Errorf("containsPoint(%v, %v) = %v, want %v", tt.x, tt.y, got, tt.want)
+			}
+		})
+	}
+}
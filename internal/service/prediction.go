@@ -0,0 +1,41 @@
+package service
+
+import "math"
+
+// PredictNextHour forecasts the next bucket's request count from a
+// historical series of per-bucket counts using an exponentially weighted
+// moving average: s_t = alpha*x_t + (1-alpha)*s_{t-1}. Confidence is
+// 1 - normalized stddev (stddev / mean) over the series, clamped to
+// [0, 1], so a noisy series yields a lower confidence than a stable one.
+func PredictNextHour(counts []float64, alpha float64) (predicted, confidence float64) {
+	if len(counts) == 0 {
+		return 0, 0
+	}
+
+	smoothed := counts[0]
+	for _, x := range counts[1:] {
+		smoothed = alpha*x + (1-alpha)*smoothed
+	}
+
+	var mean float64
+	for _, x := range counts {
+		mean += x
+	}
+	mean /= float64(len(counts))
+
+	var variance float64
+	for _, x := range counts {
+		variance += (x - mean) * (x - mean)
+	}
+	variance /= float64(len(counts))
+
+	confidence = 1.0
+	if mean > 0 {
+		confidence = 1 - math.Sqrt(variance)/mean
+	}
+	if confidence < 0 {
+		confidence = 0
+	}
+
+	return smoothed, confidence
+}
@@ -0,0 +1,39 @@
+package service
+
+import "testing"
+
+func almostEqual(a, b float64) bool {
+	const epsilon = 1e-9
+	diff := a - b
+	if diff < 0 {
+		diff = -diff
+	}
+	return diff < epsilon
+}
+
+func TestPredictNextHour(t *testing.T) {
+	tests := []struct {
+		name           string
+		counts         []float64
+		alpha          float64
+		wantPredicted  float64
+		wantConfidence float64
+	}{
+		{"empty series", nil, 0.3, 0, 0},
+		{"single zero bucket", []float64{0}, 0.3, 0, 1},
+		{"stable series has full confidence", []float64{10, 10, 10}, 0.3, 10, 1},
+		{"noisy series has zero confidence", []float64{0, 20, 0, 20}, 0.5, 12.5, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			predicted, confidence := PredictNextHour(tt.counts, tt.alpha)
+			if !almostEqual(predicted, tt.wantPredicted) {
+				t.Errorf("predicted = %v, want %v", predicted, tt.wantPredicted)
+			}
+			if !almostEqual(confidence, tt.wantConfidence) {
+				t.Errorf("confidence = %v, want %v", confidence, tt.wantConfidence)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/umahmood/haversine"
+)
+
+// ErrNoAdminAreas is returned when ResolveByCoords is called before any
+// polygons have been loaded.
+var ErrNoAdminAreas = errors.New("no admin areas loaded")
+
+// ResolveByCoords finds the administrative area enclosing (lat, lon). It
+// first bbox-prefilters areas and runs the ray-casting point-in-polygon
+// test against the survivors; if none contain the point (e.g. it falls in
+// a gap between simplified polygons), it falls back to the area whose
+// centroid is nearest by haversine distance.
+func ResolveByCoords(areas []AdminArea, lat, lon float64) (string, error) {
+	if len(areas) == 0 {
+		return "", ErrNoAdminAreas
+	}
+
+	for _, area := range areas {
+		if !area.containsBBox(lon, lat) {
+			continue
+		}
+		if area.containsPoint(lon, lat) {
+			return area.Name, nil
+		}
+	}
+
+	nearest := areas[0]
+	_, nearestKm := haversine.Distance(
+		haversine.Coord{Lat: lat, Lon: lon},
+		haversine.Coord{Lat: nearest.Centroid[1], Lon: nearest.Centroid[0]},
+	)
+	for _, area := range areas[1:] {
+		_, km := haversine.Distance(
+			haversine.Coord{Lat: lat, Lon: lon},
+			haversine.Coord{Lat: area.Centroid[1], Lon: area.Centroid[0]},
+		)
+		if km < nearestKm {
+			nearest, nearestKm = area, km
+		}
+	}
+
+	return nearest.Name, nil
+}
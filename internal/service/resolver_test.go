@@ -0,0 +1,68 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/umahmood/haversine"
+)
+
+func TestResolveByCoords(t *testing.T) {
+	areaA := square("areaA")
+	areaB := square("areaB")
+	areaB.Ring = [][2]float64{{20, 20}, {20, 30}, {30, 30}, {30, 20}}
+	areaB.MinX, areaB.MinY, areaB.MaxX, areaB.MaxY = 20, 20, 30, 30
+	areaB.Centroid = [2]float64{25, 25}
+
+	areas := []AdminArea{areaA, areaB}
+
+	t.Run("point inside a polygon", func(t *testing.T) {
+		got, err := ResolveByCoords(areas, 5, 5)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got != "areaA" {
+			t.Errorf("got %q, want %q", got, "areaA")
+		}
+	})
+
+	t.Run("point outside every bbox falls back to nearest centroid", func(t *testing.T) {
+		// Pick a point outside both bboxes and work out which centroid is
+		// actually nearer using the same haversine formula ResolveByCoords
+		// uses, rather than assuming Euclidean coordinate distance - the
+		// two can disagree, especially near the poles or across large
+		// spans.
+		lat, lon := 12.0, 12.0
+		if areaA.containsBBox(lon, lat) || areaB.containsBBox(lon, lat) {
+			t.Fatalf("test point (%v, %v) must fall outside every bbox", lat, lon)
+		}
+
+		_, distA := haversine.Distance(
+			haversine.Coord{Lat: lat, Lon: lon},
+			haversine.Coord{Lat: areaA.Centroid[1], Lon: areaA.Centroid[0]},
+		)
+		_, distB := haversine.Distance(
+			haversine.Coord{Lat: lat, Lon: lon},
+			haversine.Coord{Lat: areaB.Centroid[1], Lon: areaB.Centroid[0]},
+		)
+
+		want := areaA.Name
+		if distB < distA {
+			want = areaB.Name
+		}
+
+		got, err := ResolveByCoords(areas, lat, lon)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got != want {
+			t.Errorf("got %q, want %q (distA=%.1fkm distB=%.1fkm)", got, want, distA, distB)
+		}
+	})
+
+	t.Run("no admin areas", func(t *testing.T) {
+		_, err := ResolveByCoords(nil, 5, 5)
+		if err != ErrNoAdminAreas {
+			t.Errorf("got err %v, want %v", err, ErrNoAdminAreas)
+		}
+	})
+}